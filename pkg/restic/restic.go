@@ -0,0 +1,408 @@
+package restic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/resticui/gen/go/v1"
+)
+
+// Repo is a wrapper around a restic repository that shells out to the restic binary
+// to perform operations against it. A Repo is safe for concurrent use by multiple
+// goroutines; each operation spawns its own restic subprocess. The repository's
+// password may be rotated concurrently with in-flight operations via ChangePassword;
+// mu guards access to it.
+type Repo struct {
+	uri string
+
+	mu       sync.Mutex
+	password string
+
+	extraArgs []string
+	extraEnv  []string
+}
+
+// GenericOption is applied when constructing a Repo or on a per-call basis to add
+// extra flags / environment variables to the restic invocation.
+type GenericOption func(opts *genericOpts)
+
+type genericOpts struct {
+	extraArgs []string
+	extraEnv  []string
+	tags      []string
+}
+
+func resolveOpts(opts []GenericOption) *genericOpts {
+	o := &genericOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithFlags adds extra flags to the restic command line, e.g. WithFlags("--no-cache").
+func WithFlags(flags ...string) GenericOption {
+	return func(opts *genericOpts) {
+		opts.extraArgs = append(opts.extraArgs, flags...)
+	}
+}
+
+// WithEnv adds extra environment variables to the restic subprocess, in KEY=VALUE form.
+func WithEnv(env ...string) GenericOption {
+	return func(opts *genericOpts) {
+		opts.extraEnv = append(opts.extraEnv, env...)
+	}
+}
+
+// WithTags filters an operation (e.g. Snapshots) to the given tags.
+func WithTags(tags ...string) GenericOption {
+	return func(opts *genericOpts) {
+		opts.tags = append(opts.tags, tags...)
+	}
+}
+
+// NewRepo constructs a Repo from a v1.Repo proto describing its URI and credentials.
+func NewRepo(repoProto *v1.Repo, opts ...GenericOption) *Repo {
+	o := resolveOpts(opts)
+	return &Repo{
+		uri:       repoProto.Uri,
+		password:  repoProto.Password,
+		extraArgs: o.extraArgs,
+		extraEnv:  o.extraEnv,
+	}
+}
+
+func (r *Repo) env() []string {
+	env := os.Environ()
+	env = append(env, "RESTIC_REPOSITORY="+r.uri, "RESTIC_PASSWORD="+r.getPassword())
+	env = append(env, r.extraEnv...)
+	return env
+}
+
+// getPassword returns the repo's current password, guarding against concurrent
+// rotation via ChangePassword.
+func (r *Repo) getPassword() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.password
+}
+
+// setPassword updates the repo's password, guarding against concurrent reads via env().
+func (r *Repo) setPassword(password string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.password = password
+}
+
+// command builds an *exec.Cmd for the given restic subcommand and arguments, wiring in
+// the repository's URI/password and any extra flags configured on the Repo.
+func (r *Repo) command(ctx context.Context, args []string, opts ...GenericOption) *exec.Cmd {
+	o := resolveOpts(opts)
+
+	allArgs := make([]string, 0, len(args)+len(r.extraArgs)+len(o.extraArgs))
+	allArgs = append(allArgs, args...)
+	allArgs = append(allArgs, r.extraArgs...)
+	allArgs = append(allArgs, o.extraArgs...)
+
+	cmd := exec.CommandContext(ctx, "restic", allArgs...)
+	cmd.Env = append(r.env(), o.extraEnv...)
+	return cmd
+}
+
+// runJSONLines runs cmd, scanning stdout line by line and invoking onLine for each line.
+// stderr is captured and returned as part of the error if the command fails.
+func runJSONLines(cmd *exec.Cmd, onLine func(line []byte) error) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("stdout pipe: %w", err)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start restic: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var lineErr error
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := onLine(line); err != nil {
+			lineErr = err
+			break
+		}
+	}
+
+	waitErr := cmd.Wait()
+	if lineErr != nil {
+		return lineErr
+	}
+	if waitErr != nil {
+		return fmt.Errorf("restic %s: %w: %s", cmd.Args[1], waitErr, stderr.String())
+	}
+	return scanner.Err()
+}
+
+// Init initializes the repository.
+func (r *Repo) Init(ctx context.Context, opts ...GenericOption) error {
+	cmd := r.command(ctx, []string{"init", "--json"}, opts...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("init repo: %w: %s", err, string(out))
+	}
+	return nil
+}
+
+// BackupOption configures a single Backup invocation.
+type BackupOption func(opts *backupOpts)
+
+type backupOpts struct {
+	paths    []string
+	excludes []string
+	tags     []string
+}
+
+// WithBackupPaths adds one or more paths to the set of paths backed up. May be called
+// multiple times to back up multiple paths in a single snapshot.
+func WithBackupPaths(paths ...string) BackupOption {
+	return func(opts *backupOpts) {
+		opts.paths = append(opts.paths, paths...)
+	}
+}
+
+// WithBackupExcludes adds restic --exclude patterns to the backup.
+func WithBackupExcludes(patterns ...string) BackupOption {
+	return func(opts *backupOpts) {
+		opts.excludes = append(opts.excludes, patterns...)
+	}
+}
+
+// WithBackupTags tags the resulting snapshot.
+func WithBackupTags(tags ...string) BackupOption {
+	return func(opts *backupOpts) {
+		opts.tags = append(opts.tags, tags...)
+	}
+}
+
+// BackupProgressEntry mirrors a single JSON line emitted by `restic backup --json`, which
+// is either a periodic "status" message or the terminal "summary" message.
+type BackupProgressEntry struct {
+	MessageType string `json:"message_type"` // "status" or "summary"
+
+	// status fields
+	PercentDone  float64  `json:"percent_done"`
+	TotalFiles   int      `json:"total_files"`
+	FilesDone    int      `json:"files_done"`
+	TotalBytes   int64    `json:"total_bytes"`
+	BytesDone    int64    `json:"bytes_done"`
+	CurrentFiles []string `json:"current_files"`
+
+	// summary fields
+	FilesNew            int    `json:"files_new"`
+	FilesChanged        int    `json:"files_changed"`
+	FilesUnmodified     int    `json:"files_unmodified"`
+	TotalFilesProcessed int    `json:"total_files_processed"`
+	TotalBytesProcessed int64  `json:"total_bytes_processed"`
+	SnapshotId          string `json:"snapshot_id"`
+}
+
+// Backup runs `restic backup` with the given options, invoking progressCb (if non-nil)
+// for each status/summary event streamed from restic, and returns the terminal summary.
+func (r *Repo) Backup(ctx context.Context, progressCb func(*BackupProgressEntry), opts ...BackupOption) (*BackupProgressEntry, error) {
+	bo := &backupOpts{}
+	for _, opt := range opts {
+		opt(bo)
+	}
+
+	if len(bo.paths) == 0 {
+		return nil, fmt.Errorf("no paths specified for backup")
+	}
+
+	args := []string{"backup", "--json"}
+	args = append(args, bo.paths...)
+	for _, exclude := range bo.excludes {
+		args = append(args, "--exclude", exclude)
+	}
+	for _, tag := range bo.tags {
+		args = append(args, "--tag", tag)
+	}
+
+	cmd := r.command(ctx, args)
+
+	var summary *BackupProgressEntry
+	err := runJSONLines(cmd, func(line []byte) error {
+		var entry BackupProgressEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("parse backup progress: %w", err)
+		}
+		if progressCb != nil {
+			progressCb(&entry)
+		}
+		if entry.MessageType == "summary" {
+			e := entry
+			summary = &e
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		return nil, fmt.Errorf("backup did not produce a summary")
+	}
+	return summary, nil
+}
+
+// Snapshot describes a single restic snapshot as returned by `restic snapshots --json`.
+type Snapshot struct {
+	Id       string   `json:"id"`
+	Time     string   `json:"time"`
+	Hostname string   `json:"hostname"`
+	Tags     []string `json:"tags"`
+	Paths    []string `json:"paths"`
+}
+
+// UnixTimeMs returns the snapshot's creation time in milliseconds since the Unix epoch,
+// or 0 if the timestamp could not be parsed.
+func (s *Snapshot) UnixTimeMs() int64 {
+	t, err := time.Parse(time.RFC3339Nano, s.Time)
+	if err != nil {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// Snapshots lists the snapshots in the repository, optionally filtered via WithTags.
+func (r *Repo) Snapshots(ctx context.Context, opts ...GenericOption) ([]*Snapshot, error) {
+	o := resolveOpts(opts)
+
+	args := []string{"snapshots", "--json"}
+	for _, tag := range o.tags {
+		args = append(args, "--tag", tag)
+	}
+
+	cmd := r.command(ctx, args, opts...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+
+	var snapshots []*Snapshot
+	if err := json.Unmarshal(out, &snapshots); err != nil {
+		return nil, fmt.Errorf("parse snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+// LsEntry describes a single file or directory entry returned by `restic ls --json`.
+type LsEntry struct {
+	Name    string   `json:"name"`
+	Path    string   `json:"path"`
+	Type    string   `json:"type"`
+	Size    int64    `json:"size"`
+	Mode    uint32   `json:"mode"`
+	Mtime   string   `json:"mtime"`
+	Content []string `json:"content,omitempty"`
+}
+
+// ListDirectory lists the contents of path as of snapshotId, returning the resolved
+// snapshot followed by its entries.
+func (r *Repo) ListDirectory(ctx context.Context, snapshotId string, path string) (*Snapshot, []*LsEntry, error) {
+	cmd := r.command(ctx, []string{"ls", "--json", snapshotId, path})
+
+	var snapshot *Snapshot
+	var entries []*LsEntry
+	err := runJSONLines(cmd, func(line []byte) error {
+		var header struct {
+			StructType string `json:"struct_type"`
+		}
+		if err := json.Unmarshal(line, &header); err != nil {
+			return fmt.Errorf("parse ls output: %w", err)
+		}
+		switch header.StructType {
+		case "snapshot":
+			var s Snapshot
+			if err := json.Unmarshal(line, &s); err != nil {
+				return fmt.Errorf("parse ls snapshot header: %w", err)
+			}
+			snapshot = &s
+		case "node":
+			var e LsEntry
+			if err := json.Unmarshal(line, &e); err != nil {
+				return fmt.Errorf("parse ls node: %w", err)
+			}
+			entries = append(entries, &e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return snapshot, entries, nil
+}
+
+// RetentionPolicy describes which snapshots `restic forget` should keep.
+type RetentionPolicy struct {
+	KeepLastN int
+}
+
+func (p RetentionPolicy) toArgs() []string {
+	var args []string
+	if p.KeepLastN > 0 {
+		args = append(args, "--keep-last", strconv.Itoa(p.KeepLastN))
+	}
+	return args
+}
+
+// ForgetResult is the parsed output of `restic forget --json`.
+type ForgetResult struct {
+	Keep   []*Snapshot
+	Remove []*Snapshot
+}
+
+// Forget applies policy to the repository's snapshots, removing any that fall outside of
+// it. Human-readable prune output is copied to output as it streams from restic.
+func (r *Repo) Forget(ctx context.Context, policy RetentionPolicy, output io.Writer) (*ForgetResult, error) {
+	args := []string{"forget", "--json", "--prune"}
+	args = append(args, policy.toArgs()...)
+
+	cmd := r.command(ctx, args)
+	var stdout bytes.Buffer
+	cmd.Stdout = io.MultiWriter(&stdout, output)
+	var stderr bytes.Buffer
+	cmd.Stderr = io.MultiWriter(&stderr, output)
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("forget: %w: %s", err, stderr.String())
+	}
+
+	var groups []struct {
+		Keep   []*Snapshot `json:"keep"`
+		Remove []*Snapshot `json:"remove"`
+	}
+	dec := json.NewDecoder(strings.NewReader(stdout.String()))
+	if err := dec.Decode(&groups); err != nil {
+		return nil, fmt.Errorf("parse forget output: %w", err)
+	}
+
+	result := &ForgetResult{}
+	for _, g := range groups {
+		result.Keep = append(result.Keep, g.Keep...)
+		result.Remove = append(result.Remove, g.Remove...)
+	}
+	return result, nil
+}
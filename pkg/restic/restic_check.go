@@ -0,0 +1,120 @@
+package restic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// CheckOption configures a single Check invocation.
+type CheckOption func(opts *checkOpts)
+
+type checkOpts struct {
+	readData       bool
+	readDataSubset string
+	checkUnused    bool
+}
+
+// WithCheckReadData reads and verifies the contents of every pack in the repository.
+func WithCheckReadData() CheckOption {
+	return func(opts *checkOpts) {
+		opts.readData = true
+	}
+}
+
+// WithCheckReadDataSubset reads and verifies a subset of packs, e.g. "5%" or "2/7".
+func WithCheckReadDataSubset(spec string) CheckOption {
+	return func(opts *checkOpts) {
+		opts.readDataSubset = spec
+	}
+}
+
+// WithCheckUnused also reports unused blobs as warnings.
+func WithCheckUnused() CheckOption {
+	return func(opts *checkOpts) {
+		opts.checkUnused = true
+	}
+}
+
+// CheckProgressEntry reports progress on a single pack as `restic check` verifies it.
+type CheckProgressEntry struct {
+	PackID string
+}
+
+// CheckResult summarizes the outcome of a Check invocation.
+type CheckResult struct {
+	Errors       int
+	Warnings     int
+	CorruptPacks []string
+}
+
+var packIDPattern = regexp.MustCompile(`pack ([0-9a-f]{64})`)
+
+// unusedPattern matches the informational lines restic prints for each unreferenced
+// pack/blob when --check-unused is passed; these are reported as warnings, not errors.
+var unusedPattern = regexp.MustCompile(`(?i)unused`)
+
+// Check runs `restic check` against the repository, streaming per-pack progress through
+// progressCb, and returns a summary of errors/warnings found.
+//
+// restic check has no --json output (unlike the other commands in this package), so its
+// human-readable stdout/stderr is scraped with regexes. This is inherently more fragile
+// to upstream wording changes than the JSON-based wrappers, but it's the only option
+// restic currently offers for this command.
+func (r *Repo) Check(ctx context.Context, progressCb func(*CheckProgressEntry), opts ...CheckOption) (*CheckResult, error) {
+	co := &checkOpts{}
+	for _, opt := range opts {
+		opt(co)
+	}
+
+	args := []string{"check"}
+	if co.readData {
+		args = append(args, "--read-data")
+	} else if co.readDataSubset != "" {
+		args = append(args, "--read-data-subset", co.readDataSubset)
+	}
+	if co.checkUnused {
+		args = append(args, "--check-unused")
+	}
+
+	cmd := r.command(ctx, args)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start restic check: %w", err)
+	}
+
+	result := &CheckResult{}
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if matches := packIDPattern.FindStringSubmatch(line); matches != nil && progressCb != nil {
+			progressCb(&CheckProgressEntry{PackID: matches[1]})
+		}
+		if co.checkUnused && unusedPattern.MatchString(line) {
+			result.Warnings++
+		}
+	}
+
+	runErr := cmd.Wait()
+
+	for _, matches := range packIDPattern.FindAllStringSubmatch(stderr.String(), -1) {
+		result.CorruptPacks = append(result.CorruptPacks, matches[1])
+	}
+	result.Errors = len(result.CorruptPacks)
+
+	if runErr != nil {
+		if result.Errors == 0 {
+			return nil, fmt.Errorf("check: %w: %s", runErr, stderr.String())
+		}
+		return result, fmt.Errorf("check: %w", runErr)
+	}
+	return result, nil
+}
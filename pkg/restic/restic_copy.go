@@ -0,0 +1,70 @@
+package restic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// CopyOption configures a single Copy invocation.
+type CopyOption func(opts *copyOpts)
+
+type copyOpts struct {
+	snapshotIDs []string
+}
+
+// WithCopySnapshots adds the given snapshot IDs to those passed to Copy. If no snapshot
+// ID is given either way, all snapshots missing from the destination repository are copied.
+func WithCopySnapshots(snapshotIDs ...string) CopyOption {
+	return func(opts *copyOpts) {
+		opts.snapshotIDs = append(opts.snapshotIDs, snapshotIDs...)
+	}
+}
+
+// CopyProgressEntry mirrors a single JSON line emitted by `restic copy --json`.
+type CopyProgressEntry struct {
+	MessageType     string `json:"message_type"` // "verbose_status" or "summary"
+	SnapshotId      string `json:"snapshot_id"`
+	FilesCopied     int    `json:"files_copied"`
+	BytesCopied     int64  `json:"bytes_copied"`
+	SnapshotsCopied int    `json:"snapshots_copied"`
+}
+
+// CopySummary summarizes the snapshots that were copied to the destination repository.
+type CopySummary struct {
+	CopiedSnapshotIds []string
+}
+
+// Copy copies snapshotIDs (or all snapshots not already present at the destination, if
+// snapshotIDs is empty) from r into dest, streaming progress through progressCb.
+func (r *Repo) Copy(ctx context.Context, dest *Repo, snapshotIDs []string, progressCb func(*CopyProgressEntry), opts ...CopyOption) (*CopySummary, error) {
+	co := &copyOpts{snapshotIDs: snapshotIDs}
+	for _, opt := range opts {
+		opt(co)
+	}
+
+	args := []string{"copy", "--json", "--repo2", dest.uri}
+	args = append(args, co.snapshotIDs...)
+
+	cmd := r.command(ctx, args)
+	cmd.Env = append(cmd.Env, "RESTIC_PASSWORD2="+dest.getPassword())
+
+	summary := &CopySummary{}
+	err := runJSONLines(cmd, func(line []byte) error {
+		var entry CopyProgressEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("parse copy progress: %w", err)
+		}
+		if progressCb != nil {
+			progressCb(&entry)
+		}
+		if entry.SnapshotId != "" {
+			summary.CopiedSnapshotIds = append(summary.CopiedSnapshotIds, entry.SnapshotId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
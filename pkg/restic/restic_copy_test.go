@@ -0,0 +1,81 @@
+package restic
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/garethgeorge/resticui/gen/go/v1"
+	test "github.com/garethgeorge/resticui/internal/test/helpers"
+)
+
+func TestResticCopy(t *testing.T) {
+	t.Parallel()
+
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+
+	src := NewRepo(&v1.Repo{
+		Id:       "src",
+		Uri:      srcDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if err := src.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init source repo: %v", err)
+	}
+
+	dst := NewRepo(&v1.Repo{
+		Id:       "dst",
+		Uri:      dstDir,
+		Password: "test2",
+	}, WithFlags("--no-cache"))
+	if err := dst.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init destination repo: %v", err)
+	}
+
+	testData := test.CreateTestData(t)
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		summary, err := src.Backup(context.Background(), nil, WithBackupPaths(testData))
+		if err != nil {
+			t.Fatalf("failed to backup: %v", err)
+		}
+		ids = append(ids, summary.SnapshotId)
+	}
+
+	var events []*CopyProgressEntry
+	copySummary, err := src.Copy(context.Background(), dst, ids[:2], func(event *CopyProgressEntry) {
+		events = append(events, event)
+	})
+	if err != nil {
+		t.Fatalf("failed to copy snapshots: %v", err)
+	}
+
+	if len(copySummary.CopiedSnapshotIds) != 2 {
+		t.Errorf("wanted 2 copied snapshot ids, got: %d", len(copySummary.CopiedSnapshotIds))
+	}
+
+	if len(events) == 0 {
+		t.Errorf("wanted at least one progress event, got none")
+	}
+
+	dstSnapshots, err := dst.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list destination snapshots: %v", err)
+	}
+	if len(dstSnapshots) != 2 {
+		t.Errorf("wanted 2 snapshots on destination, got: %d", len(dstSnapshots))
+	}
+
+	_, srcEntries, err := src.ListDirectory(context.Background(), ids[0], testData)
+	if err != nil {
+		t.Fatalf("failed to list source directory: %v", err)
+	}
+	_, dstEntries, err := dst.ListDirectory(context.Background(), dstSnapshots[0].Id, testData)
+	if err != nil {
+		t.Fatalf("failed to list destination directory: %v", err)
+	}
+	if len(srcEntries) != len(dstEntries) {
+		t.Errorf("wanted copied snapshot to have %d entries, got: %d", len(srcEntries), len(dstEntries))
+	}
+}
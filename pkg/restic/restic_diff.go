@@ -0,0 +1,90 @@
+package restic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DiffEntry describes a single file or directory that changed between two snapshots.
+type DiffEntry struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// DiffResult is the structured result of diffing two snapshots, as computed from
+// `restic diff --json` output.
+type DiffResult struct {
+	Added     []*DiffEntry
+	Removed   []*DiffEntry
+	Changed   []*DiffEntry
+	Unchanged []*DiffEntry
+
+	AddedBytes   int64
+	RemovedBytes int64
+	ChangedNodes int
+}
+
+type diffStatsLine struct {
+	MessageType string `json:"message_type"` // "statistics"
+	Added       struct {
+		Bytes int64 `json:"bytes"`
+	} `json:"added"`
+	Removed struct {
+		Bytes int64 `json:"bytes"`
+	} `json:"removed"`
+	ChangedNodes int `json:"changed_files"`
+}
+
+// Diff computes the file-level differences between snapshotA and snapshotB.
+func (r *Repo) Diff(ctx context.Context, snapshotA string, snapshotB string) (*DiffResult, error) {
+	cmd := r.command(ctx, []string{"diff", "--json", snapshotA, snapshotB})
+
+	result := &DiffResult{}
+	err := runJSONLines(cmd, func(line []byte) error {
+		var header struct {
+			MessageType string `json:"message_type"`
+		}
+		if err := json.Unmarshal(line, &header); err != nil {
+			return fmt.Errorf("parse diff output: %w", err)
+		}
+
+		switch header.MessageType {
+		case "statistics":
+			var stats diffStatsLine
+			if err := json.Unmarshal(line, &stats); err != nil {
+				return fmt.Errorf("parse diff statistics: %w", err)
+			}
+			result.AddedBytes = stats.Added.Bytes
+			result.RemovedBytes = stats.Removed.Bytes
+			result.ChangedNodes = stats.ChangedNodes
+		case "change":
+			var change struct {
+				Path    string `json:"path"`
+				ModType string `json:"modifier"` // "+", "-", "M", or "U"
+				NewStat struct {
+					Size int64 `json:"size"`
+				} `json:"new_stat"`
+			}
+			if err := json.Unmarshal(line, &change); err != nil {
+				return fmt.Errorf("parse diff change: %w", err)
+			}
+			entry := &DiffEntry{Path: change.Path, Size: change.NewStat.Size}
+			switch change.ModType {
+			case "+":
+				result.Added = append(result.Added, entry)
+			case "-":
+				result.Removed = append(result.Removed, entry)
+			case "M":
+				result.Changed = append(result.Changed, entry)
+			default:
+				result.Unchanged = append(result.Unchanged, entry)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package restic
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/garethgeorge/resticui/gen/go/v1"
+	test "github.com/garethgeorge/resticui/internal/test/helpers"
+)
+
+func TestResticDiff(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	r := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := test.CreateTestData(t)
+
+	summaryA, err := r.Backup(context.Background(), nil, WithBackupPaths(testData))
+	if err != nil {
+		t.Fatalf("failed to create first snapshot: %v", err)
+	}
+
+	// add a file
+	if err := os.WriteFile(filepath.Join(testData, "added.txt"), []byte("added"), 0644); err != nil {
+		t.Fatalf("failed to add file: %v", err)
+	}
+	// remove a file
+	if err := os.Remove(filepath.Join(testData, "file0")); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+	// modify a file
+	if err := os.WriteFile(filepath.Join(testData, "file1"), []byte("modified contents"), 0644); err != nil {
+		t.Fatalf("failed to modify file: %v", err)
+	}
+	// rename a file (appears as removed + added)
+	if err := os.Rename(filepath.Join(testData, "file2"), filepath.Join(testData, "renamed.txt")); err != nil {
+		t.Fatalf("failed to rename file: %v", err)
+	}
+
+	summaryB, err := r.Backup(context.Background(), nil, WithBackupPaths(testData))
+	if err != nil {
+		t.Fatalf("failed to create second snapshot: %v", err)
+	}
+
+	diff, err := r.Diff(context.Background(), summaryA.SnapshotId, summaryB.SnapshotId)
+	if err != nil {
+		t.Fatalf("failed to diff snapshots: %v", err)
+	}
+
+	if len(diff.Added) < 2 {
+		t.Errorf("wanted at least 2 added files (added.txt, renamed.txt), got: %d", len(diff.Added))
+	}
+	if len(diff.Removed) < 2 {
+		t.Errorf("wanted at least 2 removed files (file0, file2), got: %d", len(diff.Removed))
+	}
+	if len(diff.Changed) < 1 {
+		t.Errorf("wanted at least 1 changed file (file1), got: %d", len(diff.Changed))
+	}
+	if diff.AddedBytes <= 0 {
+		t.Errorf("wanted positive added bytes, got: %d", diff.AddedBytes)
+	}
+	if diff.RemovedBytes <= 0 {
+		t.Errorf("wanted positive removed bytes, got: %d", diff.RemovedBytes)
+	}
+	if diff.ChangedNodes < 1 {
+		t.Errorf("wanted at least 1 changed node, got: %d", diff.ChangedNodes)
+	}
+}
@@ -0,0 +1,45 @@
+package restic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+// DumpOption configures a single Dump invocation.
+type DumpOption func(opts *dumpOpts)
+
+type dumpOpts struct {
+	archive string
+}
+
+// WithDumpArchive selects the archive format used when dumping a directory, either
+// "tar" (the default) or "zip". Ignored when dumping a single file.
+func WithDumpArchive(format string) DumpOption {
+	return func(opts *dumpOpts) {
+		opts.archive = format
+	}
+}
+
+// Dump writes the contents of path as of snapshotID to w. A single file is written as
+// its raw bytes; a directory is written as a tar (or zip, via WithDumpArchive) stream.
+func (r *Repo) Dump(ctx context.Context, snapshotID string, path string, w io.Writer, opts ...DumpOption) error {
+	do := &dumpOpts{archive: "tar"}
+	for _, opt := range opts {
+		opt(do)
+	}
+
+	args := []string{"dump", "--archive", do.archive, snapshotID, path}
+
+	cmd := r.command(ctx, args)
+	cmd.Stdout = w
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("dump %s:%s: %w: %s", snapshotID, path, err, stderr.String())
+	}
+	return nil
+}
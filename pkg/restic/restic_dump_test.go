@@ -0,0 +1,133 @@
+package restic
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "github.com/garethgeorge/resticui/gen/go/v1"
+	test "github.com/garethgeorge/resticui/internal/test/helpers"
+)
+
+func TestResticDumpFile(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	r := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := test.CreateTestData(t)
+	filePath := filepath.Join(testData, "file1")
+	wantContents, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read source file: %v", err)
+	}
+
+	summary, err := r.Backup(context.Background(), nil, WithBackupPaths(testData))
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Dump(context.Background(), summary.SnapshotId, filePath, &buf); err != nil {
+		t.Fatalf("failed to dump file: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), wantContents) {
+		t.Errorf("dumped file contents did not match source")
+	}
+}
+
+func TestResticDumpDirectory(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	r := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := test.CreateTestData(t)
+	summary, err := r.Backup(context.Background(), nil, WithBackupPaths(testData))
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Dump(context.Background(), summary.SnapshotId, testData, &buf, WithDumpArchive("tar")); err != nil {
+		t.Fatalf("failed to dump directory: %v", err)
+	}
+
+	got := make(map[string][]byte)
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read tar stream: %v", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("failed to read tar entry: %v", err)
+		}
+		got[filepath.ToSlash(hdr.Name)] = contents
+	}
+
+	err = filepath.WalkDir(testData, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(testData, path)
+		if err != nil {
+			return err
+		}
+		relSlash := filepath.ToSlash(rel)
+
+		var contents []byte
+		var ok bool
+		for name, c := range got {
+			if name == relSlash || strings.HasSuffix(name, "/"+relSlash) {
+				contents, ok = c, true
+				break
+			}
+		}
+		if !ok {
+			t.Errorf("expected %s in dumped tar stream", relSlash)
+			return nil
+		}
+		if !bytes.Equal(contents, want) {
+			t.Errorf("dumped contents for %s did not match source", filepath.Base(path))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk test data: %v", err)
+	}
+}
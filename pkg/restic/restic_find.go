@@ -0,0 +1,117 @@
+package restic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// FindOption configures a single Find invocation.
+type FindOption func(opts *findOpts)
+
+type findOpts struct {
+	snapshotID string
+	blobID     string
+	packID     string
+	newestOnly bool
+	pathOnly   bool
+}
+
+// WithFindSnapshot restricts the search to a single snapshot.
+func WithFindSnapshot(id string) FindOption {
+	return func(opts *findOpts) {
+		opts.snapshotID = id
+	}
+}
+
+// WithFindBlob searches for the snapshot(s) containing the given blob ID.
+func WithFindBlob(blobID string) FindOption {
+	return func(opts *findOpts) {
+		opts.blobID = blobID
+	}
+}
+
+// WithFindPack searches for the snapshot(s) referencing the given pack ID.
+func WithFindPack(packID string) FindOption {
+	return func(opts *findOpts) {
+		opts.packID = packID
+	}
+}
+
+// WithFindNewestOnly limits the results to the newest matching snapshot.
+func WithFindNewestOnly() FindOption {
+	return func(opts *findOpts) {
+		opts.newestOnly = true
+	}
+}
+
+// WithFindPathOnly matches pattern only against the full path, not the filename.
+func WithFindPathOnly() FindOption {
+	return func(opts *findOpts) {
+		opts.pathOnly = true
+	}
+}
+
+// FindMatch is a single file matched by Find within a snapshot.
+type FindMatch struct {
+	Path    string   `json:"path"`
+	Size    int64    `json:"size"`
+	Mtime   string   `json:"mtime"`
+	Mode    uint32   `json:"mode"`
+	BlobIDs []string `json:"blobs,omitempty"`
+}
+
+// FindResult groups the matches found within a single snapshot.
+type FindResult struct {
+	SnapshotID string
+	Matches    []*FindMatch
+}
+
+// Find searches the repository for pattern, which may be a filename or a glob, returning
+// matches grouped by snapshot. With WithFindBlob or WithFindPack, pattern is ignored and
+// the given blob or pack ID is searched for instead.
+func (r *Repo) Find(ctx context.Context, pattern string, opts ...FindOption) ([]*FindResult, error) {
+	fo := &findOpts{}
+	for _, opt := range opts {
+		opt(fo)
+	}
+
+	args := []string{"find", "--json"}
+	switch {
+	case fo.blobID != "":
+		args = append(args, "--blob", fo.blobID)
+	case fo.packID != "":
+		args = append(args, "--pack", fo.packID)
+	default:
+		args = append(args, pattern)
+	}
+	if fo.snapshotID != "" {
+		args = append(args, "--snapshot", fo.snapshotID)
+	}
+	if fo.newestOnly {
+		args = append(args, "--newest")
+	}
+	if fo.pathOnly {
+		args = append(args, "--path")
+	}
+
+	cmd := r.command(ctx, args)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+
+	var raw []struct {
+		Matches    []*FindMatch `json:"matches"`
+		SnapshotID string       `json:"snapshot"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("parse find output: %w", err)
+	}
+
+	results := make([]*FindResult, 0, len(raw))
+	for _, r := range raw {
+		results = append(results, &FindResult{SnapshotID: r.SnapshotID, Matches: r.Matches})
+	}
+	return results, nil
+}
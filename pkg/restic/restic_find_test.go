@@ -0,0 +1,65 @@
+package restic
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/garethgeorge/resticui/gen/go/v1"
+	test "github.com/garethgeorge/resticui/internal/test/helpers"
+)
+
+func TestResticFind(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	r := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := test.CreateTestData(t)
+	summary, err := r.Backup(context.Background(), nil, WithBackupPaths(testData))
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	// search by filename glob
+	results, err := r.Find(context.Background(), "file1*")
+	if err != nil {
+		t.Fatalf("failed to find by glob: %v", err)
+	}
+	if len(results) == 0 || len(results[0].Matches) == 0 {
+		t.Errorf("wanted at least one match for file1*, got: %v", results)
+	}
+
+	// search by exact path
+	_, entries, err := r.ListDirectory(context.Background(), summary.SnapshotId, testData)
+	if err != nil {
+		t.Fatalf("failed to list directory: %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatalf("expected at least one entry from ListDirectory")
+	}
+	results, err = r.Find(context.Background(), entries[0].Path, WithFindPathOnly())
+	if err != nil {
+		t.Fatalf("failed to find by exact path: %v", err)
+	}
+	if len(results) == 0 {
+		t.Errorf("wanted a match for exact path %s, got none", entries[0].Path)
+	}
+
+	// search by blob ID obtained from the entry
+	if len(entries[0].Content) > 0 {
+		results, err = r.Find(context.Background(), "", WithFindBlob(entries[0].Content[0]))
+		if err != nil {
+			t.Fatalf("failed to find by blob id: %v", err)
+		}
+		if len(results) == 0 {
+			t.Errorf("wanted a match for blob %s, got none", entries[0].Content[0])
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package restic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// KeyInfo describes a single key registered on the repository, as returned by
+// `restic key list --json`.
+type KeyInfo struct {
+	ID       string    `json:"id"`
+	Hostname string    `json:"hostname"`
+	Username string    `json:"username"`
+	Created  time.Time `json:"created"`
+	Current  bool      `json:"current"`
+}
+
+// writeTempPasswordFile writes password to a private temp file suitable for passing to
+// restic's --password-file / --new-password-file flags, returning a cleanup function
+// that removes it.
+func writeTempPasswordFile(password string) (string, func(), error) {
+	f, err := os.CreateTemp("", "restic-password-*")
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := f.WriteString(password); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", nil, err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", nil, err
+	}
+	return f.Name(), func() { os.Remove(f.Name()) }, nil
+}
+
+// AddKey adds a new key to the repository protected by newPassword, returning the
+// resulting KeyInfo.
+func (r *Repo) AddKey(ctx context.Context, newPassword string, hostname string, username string) (*KeyInfo, error) {
+	newPasswordFile, cleanup, err := writeTempPasswordFile(newPassword)
+	if err != nil {
+		return nil, fmt.Errorf("write new password file: %w", err)
+	}
+	defer cleanup()
+
+	args := []string{"key", "add", "--new-password-file", newPasswordFile}
+	if hostname != "" {
+		args = append(args, "--host", hostname)
+	}
+	if username != "" {
+		args = append(args, "--user", username)
+	}
+
+	keysBefore, err := r.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list keys before add: %w", err)
+	}
+	idsBefore := make(map[string]bool, len(keysBefore))
+	for _, k := range keysBefore {
+		idsBefore[k.ID] = true
+	}
+
+	cmd := r.command(ctx, args)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("add key: %w: %s", err, string(out))
+	}
+
+	keysAfter, err := r.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list keys after add: %w", err)
+	}
+	for _, k := range keysAfter {
+		if !idsBefore[k.ID] {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("added key not found in key list")
+}
+
+// RemoveKey removes the key identified by keyID from the repository.
+func (r *Repo) RemoveKey(ctx context.Context, keyID string) error {
+	cmd := r.command(ctx, []string{"key", "remove", keyID})
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("remove key %s: %w: %s", keyID, err, string(out))
+	}
+	return nil
+}
+
+// ListKeys lists all keys registered on the repository.
+func (r *Repo) ListKeys(ctx context.Context) ([]*KeyInfo, error) {
+	cmd := r.command(ctx, []string{"key", "list", "--json"})
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+
+	var keys []*KeyInfo
+	if err := json.Unmarshal(out, &keys); err != nil {
+		return nil, fmt.Errorf("parse key list: %w", err)
+	}
+	return keys, nil
+}
+
+// ChangePassword replaces the repository's current password with newPassword, verifying
+// oldPassword matches the Repo's configured password first.
+func (r *Repo) ChangePassword(ctx context.Context, oldPassword string, newPassword string) error {
+	if oldPassword != r.getPassword() {
+		return fmt.Errorf("old password does not match repo's configured password")
+	}
+
+	newPasswordFile, cleanup, err := writeTempPasswordFile(newPassword)
+	if err != nil {
+		return fmt.Errorf("write new password file: %w", err)
+	}
+	defer cleanup()
+
+	cmd := r.command(ctx, []string{"key", "passwd", "--new-password-file", newPasswordFile})
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("change password: %w: %s", err, string(out))
+	}
+
+	r.setPassword(newPassword)
+	return nil
+}
@@ -0,0 +1,84 @@
+package restic
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/garethgeorge/resticui/gen/go/v1"
+)
+
+func TestResticKeyManagement(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	r := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	key, err := r.AddKey(context.Background(), "test2", "testhost", "testuser")
+	if err != nil {
+		t.Fatalf("failed to add key: %v", err)
+	}
+
+	keys, err := r.ListKeys(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list keys: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("wanted 2 keys, got: %d", len(keys))
+	}
+
+	if err := r.RemoveKey(context.Background(), key.ID); err != nil {
+		t.Fatalf("failed to remove key: %v", err)
+	}
+
+	keys, err = r.ListKeys(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list keys after remove: %v", err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("wanted 1 key after remove, got: %d", len(keys))
+	}
+
+	// a repo opened with the removed key's password should fail to operate.
+	removedKeyRepo := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test2",
+	}, WithFlags("--no-cache"))
+	if _, err := removedKeyRepo.ListKeys(context.Background()); err == nil {
+		t.Errorf("expected operation with removed key's password to fail")
+	}
+}
+
+func TestResticChangePassword(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	r := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	if err := r.ChangePassword(context.Background(), "test", "newpassword"); err != nil {
+		t.Fatalf("failed to change password: %v", err)
+	}
+
+	oldPasswordRepo := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if _, err := oldPasswordRepo.ListKeys(context.Background()); err == nil {
+		t.Errorf("expected operation with old password to fail after password change")
+	}
+}
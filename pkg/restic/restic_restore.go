@@ -0,0 +1,98 @@
+package restic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RestoreOption configures a single Restore invocation.
+type RestoreOption func(opts *restoreOpts)
+
+type restoreOpts struct {
+	includes []string
+	excludes []string
+	target   string
+	verify   bool
+}
+
+// WithRestoreInclude restricts the restore to paths matching pattern.
+func WithRestoreInclude(patterns ...string) RestoreOption {
+	return func(opts *restoreOpts) {
+		opts.includes = append(opts.includes, patterns...)
+	}
+}
+
+// WithRestoreExclude excludes paths matching pattern from the restore.
+func WithRestoreExclude(patterns ...string) RestoreOption {
+	return func(opts *restoreOpts) {
+		opts.excludes = append(opts.excludes, patterns...)
+	}
+}
+
+// WithRestoreTarget overrides the directory the snapshot is restored into. If not set,
+// the target passed to Restore is used.
+func WithRestoreTarget(target string) RestoreOption {
+	return func(opts *restoreOpts) {
+		opts.target = target
+	}
+}
+
+// WithRestoreVerify verifies restored file contents against the repository after the
+// restore completes.
+func WithRestoreVerify() RestoreOption {
+	return func(opts *restoreOpts) {
+		opts.verify = true
+	}
+}
+
+// RestoreProgressEntry mirrors a single JSON line emitted by `restic restore --json`.
+type RestoreProgressEntry struct {
+	MessageType    string   `json:"message_type"` // "status" or "summary"
+	SecondsElapsed float64  `json:"seconds_elapsed"`
+	PercentDone    float64  `json:"percent_done"`
+	TotalFiles     int      `json:"total_files"`
+	FilesRestored  int      `json:"files_restored"`
+	TotalBytes     int64    `json:"total_bytes"`
+	BytesRestored  int64    `json:"bytes_restored"`
+	CurrentFiles   []string `json:"current_files"`
+}
+
+// Restore restores snapshotID (optionally suffixed with ":/path" to restore a subtree)
+// into target, streaming progress through progressCb.
+func (r *Repo) Restore(ctx context.Context, snapshotID string, target string, progressCb func(*RestoreProgressEntry), opts ...RestoreOption) error {
+	ro := &restoreOpts{target: target}
+	for _, opt := range opts {
+		opt(ro)
+	}
+
+	snapshotSpec := snapshotID
+	if !strings.Contains(snapshotSpec, ":") {
+		snapshotSpec = snapshotID + ":/"
+	}
+
+	args := []string{"restore", "--json", snapshotSpec, "--target", ro.target}
+	for _, pattern := range ro.includes {
+		args = append(args, "--include", pattern)
+	}
+	for _, pattern := range ro.excludes {
+		args = append(args, "--exclude", pattern)
+	}
+	if ro.verify {
+		args = append(args, "--verify")
+	}
+
+	cmd := r.command(ctx, args)
+
+	return runJSONLines(cmd, func(line []byte) error {
+		var entry RestoreProgressEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("parse restore progress: %w", err)
+		}
+		if progressCb != nil {
+			progressCb(&entry)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,80 @@
+package restic
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/garethgeorge/resticui/gen/go/v1"
+	test "github.com/garethgeorge/resticui/internal/test/helpers"
+)
+
+func TestResticRestore(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	r := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := test.CreateTestData(t)
+	summary, err := r.Backup(context.Background(), nil, WithBackupPaths(testData))
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	restoreTarget := t.TempDir()
+
+	var events []*RestoreProgressEntry
+	if err := r.Restore(context.Background(), summary.SnapshotId, restoreTarget, func(event *RestoreProgressEntry) {
+		events = append(events, event)
+	}); err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+
+	if len(events) == 0 {
+		t.Errorf("wanted at least one progress event, got none")
+	}
+
+	var lastBytes int64
+	for _, event := range events {
+		if event.BytesRestored < lastBytes {
+			t.Errorf("wanted monotonic bytes restored, got %d after %d", event.BytesRestored, lastBytes)
+		}
+		lastBytes = event.BytesRestored
+	}
+
+	restoredData := filepath.Join(restoreTarget, testData)
+	if err := filepath.WalkDir(testData, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(testData, path)
+		if err != nil {
+			return err
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		got, err := os.ReadFile(filepath.Join(restoredData, rel))
+		if err != nil {
+			return err
+		}
+		if string(want) != string(got) {
+			t.Errorf("restored file %s did not match source", rel)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to walk test data: %v", err)
+	}
+}
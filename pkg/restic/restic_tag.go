@@ -0,0 +1,109 @@
+package restic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AddTags adds tags to each of the given snapshots, leaving any existing tags in place.
+func (r *Repo) AddTags(ctx context.Context, snapshotIDs []string, tags []string) error {
+	return r.runTagCommand(ctx, "--add", snapshotIDs, tags)
+}
+
+// RemoveTags removes tags from each of the given snapshots.
+func (r *Repo) RemoveTags(ctx context.Context, snapshotIDs []string, tags []string) error {
+	return r.runTagCommand(ctx, "--remove", snapshotIDs, tags)
+}
+
+// SetTags replaces the tag set on each of the given snapshots.
+func (r *Repo) SetTags(ctx context.Context, snapshotIDs []string, tags []string) error {
+	return r.runTagCommand(ctx, "--set", snapshotIDs, tags)
+}
+
+func (r *Repo) runTagCommand(ctx context.Context, flag string, snapshotIDs []string, tags []string) error {
+	if len(tags) == 0 {
+		return fmt.Errorf("no tags specified")
+	}
+
+	args := []string{"tag"}
+	for _, tag := range tags {
+		args = append(args, flag, tag)
+	}
+	args = append(args, snapshotIDs...)
+
+	cmd := r.command(ctx, args)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("tag %s: %w: %s", flag, err, string(out))
+	}
+	return nil
+}
+
+// WithSnapshotGroupBy configures which fields SnapshotGroups groups by.
+func WithSnapshotGroupBy(host bool, path bool, tags bool) GenericOption {
+	var fields []string
+	if host {
+		fields = append(fields, "host")
+	}
+	if path {
+		fields = append(fields, "path")
+	}
+	if tags {
+		fields = append(fields, "tag")
+	}
+	return WithFlags("--group-by", strings.Join(fields, ","))
+}
+
+// SnapshotGroup is a bucket of snapshots sharing the same group-by key.
+type SnapshotGroup struct {
+	GroupKey  SnapshotGroupKey
+	Snapshots []*Snapshot
+}
+
+// SnapshotGroupKey identifies the dimensions a SnapshotGroup was bucketed by.
+type SnapshotGroupKey struct {
+	Hostname string   `json:"hostname"`
+	Paths    []string `json:"paths"`
+	Tags     []string `json:"tags"`
+}
+
+// SnapshotGroups lists snapshots grouped according to WithSnapshotGroupBy (or ungrouped,
+// as a single group, if it was not passed).
+func (r *Repo) SnapshotGroups(ctx context.Context, opts ...GenericOption) ([]*SnapshotGroup, error) {
+	o := resolveOpts(opts)
+
+	if !slices.Contains(o.extraArgs, "--group-by") {
+		snapshots, err := r.Snapshots(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return []*SnapshotGroup{{Snapshots: snapshots}}, nil
+	}
+
+	args := []string{"snapshots", "--json"}
+	for _, tag := range o.tags {
+		args = append(args, "--tag", tag)
+	}
+
+	cmd := r.command(ctx, args, opts...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list snapshot groups: %w", err)
+	}
+
+	var raw []struct {
+		GroupKey  SnapshotGroupKey `json:"group_key"`
+		Snapshots []*Snapshot      `json:"snapshots"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("parse snapshot groups: %w", err)
+	}
+
+	groups := make([]*SnapshotGroup, 0, len(raw))
+	for _, g := range raw {
+		groups = append(groups, &SnapshotGroup{GroupKey: g.GroupKey, Snapshots: g.Snapshots})
+	}
+	return groups, nil
+}
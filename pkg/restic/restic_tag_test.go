@@ -0,0 +1,112 @@
+package restic
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/garethgeorge/resticui/gen/go/v1"
+	test "github.com/garethgeorge/resticui/internal/test/helpers"
+)
+
+func TestResticTagMutation(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	r := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := test.CreateTestData(t)
+	summary, err := r.Backup(context.Background(), nil, WithBackupPaths(testData), WithBackupTags("initial"))
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	if err := r.AddTags(context.Background(), []string{summary.SnapshotId}, []string{"added"}); err != nil {
+		t.Fatalf("failed to add tags: %v", err)
+	}
+	snapshots, err := r.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if !containsTag(snapshots[0].Tags, "added") || !containsTag(snapshots[0].Tags, "initial") {
+		t.Errorf("wanted tags to include initial and added, got: %v", snapshots[0].Tags)
+	}
+
+	if err := r.RemoveTags(context.Background(), []string{summary.SnapshotId}, []string{"initial"}); err != nil {
+		t.Fatalf("failed to remove tags: %v", err)
+	}
+	snapshots, err = r.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if containsTag(snapshots[0].Tags, "initial") {
+		t.Errorf("wanted initial tag to be removed, got: %v", snapshots[0].Tags)
+	}
+
+	if err := r.SetTags(context.Background(), []string{summary.SnapshotId}, []string{"only"}); err != nil {
+		t.Fatalf("failed to set tags: %v", err)
+	}
+	snapshots, err = r.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if len(snapshots[0].Tags) != 1 || snapshots[0].Tags[0] != "only" {
+		t.Errorf("wanted tags to be exactly [only], got: %v", snapshots[0].Tags)
+	}
+}
+
+func TestResticSnapshotGroups(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	r := NewRepo(&v1.Repo{
+		Id:       "test",
+		Uri:      repoDir,
+		Password: "test",
+	}, WithFlags("--no-cache"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := test.CreateTestData(t)
+	if _, err := r.Backup(context.Background(), nil, WithBackupPaths(testData), WithBackupTags("a")); err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+	if _, err := r.Backup(context.Background(), nil, WithBackupPaths(testData), WithBackupTags("b")); err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	groups, err := r.SnapshotGroups(context.Background(), WithSnapshotGroupBy(false, false, true))
+	if err != nil {
+		t.Fatalf("failed to list snapshot groups: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Errorf("wanted 2 groups (one per tag), got: %d", len(groups))
+	}
+
+	ungrouped, err := r.SnapshotGroups(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list ungrouped snapshots: %v", err)
+	}
+	if len(ungrouped) != 1 {
+		t.Fatalf("wanted a single ungrouped bucket, got: %d", len(ungrouped))
+	}
+	if len(ungrouped[0].Snapshots) != 2 {
+		t.Errorf("wanted the ungrouped bucket to contain all 2 snapshots, got: %d", len(ungrouped[0].Snapshots))
+	}
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}